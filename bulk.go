@@ -0,0 +1,310 @@
+package processorsdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+const defaultBulkChunkSize = 100
+
+// BulkOptions controls client-side batching for BulkCreateScripts,
+// BulkUpdateEvents and BulkDeleteScripts.
+type BulkOptions struct {
+	// Concurrency caps how many requests are in flight at once. Defaults
+	// to 4.
+	Concurrency int
+	// ChunkSize caps how many items are sent to a single bulk-endpoint
+	// request. Defaults to 100. Ignored when falling back to per-item
+	// calls.
+	ChunkSize int
+	// ContinueOnError keeps processing remaining items after a failure
+	// instead of stopping at the first one.
+	ContinueOnError bool
+	// IdempotencyKey, if set, produces an Idempotency-Key header value, so
+	// retried batches don't double-create/update/delete. It is called with
+	// each item's index for per-item calls, and once per bulk-endpoint
+	// request with the index of that chunk's first item.
+	IdempotencyKey func(i int) string
+}
+
+// BulkError records the failure of a single item within a bulk operation.
+type BulkError struct {
+	Index int
+	Err   error
+}
+
+func (e BulkError) Error() string { return fmt.Sprintf("item %d: %s", e.Index, e.Err) }
+
+// BulkResult is the outcome of a bulk operation: the items that succeeded,
+// in input order, and the items that failed with their original index.
+type BulkResult[T any] struct {
+	Succeeded []T
+	Failed    []BulkError
+}
+
+func (o BulkOptions) concurrency() int {
+	if o.Concurrency > 0 {
+		return o.Concurrency
+	}
+	return 4
+}
+
+func (o BulkOptions) chunkSize() int {
+	if o.ChunkSize > 0 {
+		return o.ChunkSize
+	}
+	return defaultBulkChunkSize
+}
+
+func (o BulkOptions) idempotencyKey(i int) string {
+	if o.IdempotencyKey == nil {
+		return ""
+	}
+	return o.IdempotencyKey(i)
+}
+
+// runBulkPerItem fans out fn over 0..n-1 using up to opts.Concurrency
+// goroutines, stopping at the first failure unless opts.ContinueOnError is
+// set. Results are returned in input order regardless of completion order.
+func runBulkPerItem[T any](ctx context.Context, n int, opts BulkOptions, fn func(ctx context.Context, i int) (T, error)) *BulkResult[T] {
+	type outcome struct {
+		item T
+		err  error
+	}
+	outcomes := make([]outcome, n)
+	started := make([]bool, n)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sem := make(chan struct{}, opts.concurrency())
+	var wg sync.WaitGroup
+	var stopOnce sync.Once
+	var failed bool
+	var mu sync.Mutex
+
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		mu.Lock()
+		stop := failed && !opts.ContinueOnError
+		mu.Unlock()
+		if stop {
+			<-sem
+			break
+		}
+
+		started[i] = true
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			item, err := fn(ctx, i)
+			outcomes[i] = outcome{item: item, err: err}
+			if err != nil {
+				mu.Lock()
+				failed = true
+				mu.Unlock()
+				if !opts.ContinueOnError {
+					stopOnce.Do(cancel)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	result := &BulkResult[T]{}
+	for i, o := range outcomes {
+		if !started[i] {
+			continue
+		}
+		if o.err != nil {
+			result.Failed = append(result.Failed, BulkError{Index: i, Err: o.err})
+			continue
+		}
+		result.Succeeded = append(result.Succeeded, o.item)
+	}
+	return result
+}
+
+// runBulkChunked drives the "submit chunks to a bulk endpoint, and from
+// the first chunk that turns out to be unsupported (404) onward, fall
+// back to per-item calls" flow shared by BulkCreateScripts,
+// BulkUpdateEvents and BulkDeleteScripts. submitChunk sends items[start,
+// end) to the bulk endpoint; perItem is the single-item fallback, called
+// only for indices from the first unsupported chunk onward. Results from
+// bulk chunks that already succeeded are preserved, not discarded, when a
+// later chunk falls back.
+func runBulkChunked[T any](ctx context.Context, n int, opts BulkOptions, submitChunk func(ctx context.Context, start, end int) ([]T, error), perItem func(ctx context.Context, i int) (T, error)) *BulkResult[T] {
+	bulkSupported := true
+	result := &BulkResult[T]{}
+	fallbackFrom := n
+
+	chunkSize := opts.chunkSize()
+	for start := 0; start < n && bulkSupported; start += chunkSize {
+		end := start + chunkSize
+		if end > n {
+			end = n
+		}
+
+		items, err := submitChunk(ctx, start, end)
+		if err != nil {
+			if apiErr, ok := IsAPIError(err); ok && apiErr.StatusCode == http.StatusNotFound {
+				bulkSupported = false
+				fallbackFrom = start
+				break
+			}
+			for i := start; i < end; i++ {
+				result.Failed = append(result.Failed, BulkError{Index: i, Err: err})
+			}
+			if !opts.ContinueOnError {
+				return result
+			}
+			continue
+		}
+		result.Succeeded = append(result.Succeeded, items...)
+	}
+
+	if bulkSupported {
+		return result
+	}
+
+	fallback := runBulkPerItem(ctx, n-fallbackFrom, opts, func(ctx context.Context, i int) (T, error) {
+		return perItem(ctx, fallbackFrom+i)
+	})
+
+	result.Succeeded = append(result.Succeeded, fallback.Succeeded...)
+	for _, f := range fallback.Failed {
+		result.Failed = append(result.Failed, BulkError{Index: fallbackFrom + f.Index, Err: f.Err})
+	}
+	return result
+}
+
+// BulkCreateScripts creates many scripts, batching them against
+// POST /api/v1/scripts/bulk when the server supports it and otherwise
+// falling back to one CreateScript call per item.
+func (c *Client) BulkCreateScripts(ctx context.Context, bodies []CreateScriptBody, opts BulkOptions) (*BulkResult[ScriptItem], error) {
+	result := runBulkChunked(ctx, len(bodies), opts,
+		func(ctx context.Context, start, end int) ([]ScriptItem, error) {
+			return c.bulkCreateScriptsChunk(ctx, bodies[start:end], opts.idempotencyKey(start))
+		},
+		func(ctx context.Context, i int) (ScriptItem, error) {
+			headers := idempotencyHeaders(opts.idempotencyKey(i))
+			path := c.baseURL + apiPathPrefix + "/scripts"
+			raw, _ := json.Marshal(bodies[i])
+			var resp GetScriptResponse
+			err := c.do(ctx, http.MethodPost, path, raw, []int{http.StatusCreated}, &resp, "failed to create script",
+				opInfo{Name: "CreateScript", Resource: "scripts", Headers: headers})
+			return resp.Data, err
+		},
+	)
+	return result, nil
+}
+
+// bulkCreateScriptsChunk submits one chunk to the bulk-create endpoint.
+func (c *Client) bulkCreateScriptsChunk(ctx context.Context, bodies []CreateScriptBody, idempotencyKey string) ([]ScriptItem, error) {
+	path := c.baseURL + apiPathPrefix + "/scripts/bulk"
+	raw, _ := json.Marshal(bodies)
+	var resp ListScriptsResponse
+	err := c.do(ctx, http.MethodPost, path, raw, []int{http.StatusCreated, http.StatusOK}, &resp, "failed to bulk create scripts",
+		opInfo{Name: "BulkCreateScripts", Resource: "scripts", Headers: idempotencyHeaders(idempotencyKey)})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// BulkEventUpdate pairs an event ID with the payload it should be updated
+// with, for use with BulkUpdateEvents.
+type BulkEventUpdate struct {
+	ID      string                 `json:"id"`
+	Payload map[string]interface{} `json:"payload"`
+}
+
+// BulkUpdateEvents updates many events, batching them against
+// PATCH /api/v1/events/bulk when the server supports it and otherwise
+// falling back to one UpdateEvent-style PUT call per item.
+func (c *Client) BulkUpdateEvents(ctx context.Context, updates []BulkEventUpdate, opts BulkOptions) (*BulkResult[EventItem], error) {
+	result := runBulkChunked(ctx, len(updates), opts,
+		func(ctx context.Context, start, end int) ([]EventItem, error) {
+			return c.bulkUpdateEventsChunk(ctx, updates[start:end], opts.idempotencyKey(start))
+		},
+		func(ctx context.Context, i int) (EventItem, error) {
+			update := updates[i]
+			if update.ID == "" {
+				return EventItem{}, fmt.Errorf("event id is required")
+			}
+			path := c.baseURL + apiPathPrefix + "/events/" + pathSeg(update.ID)
+			raw, _ := json.Marshal(map[string]interface{}{"payload": update.Payload})
+			var resp GetEventResponse
+			err := c.do(ctx, http.MethodPut, path, raw, []int{http.StatusOK}, &resp, "failed to update event",
+				opInfo{Name: "UpdateEvent", Resource: "events", ResourceID: update.ID, Headers: idempotencyHeaders(opts.idempotencyKey(i))})
+			return resp.Data, err
+		},
+	)
+	return result, nil
+}
+
+// bulkUpdateEventsChunk submits one chunk to the bulk-update endpoint.
+func (c *Client) bulkUpdateEventsChunk(ctx context.Context, updates []BulkEventUpdate, idempotencyKey string) ([]EventItem, error) {
+	path := c.baseURL + apiPathPrefix + "/events/bulk"
+	raw, _ := json.Marshal(updates)
+	var resp ListEventsResponse
+	err := c.do(ctx, http.MethodPatch, path, raw, []int{http.StatusOK}, &resp, "failed to bulk update events",
+		opInfo{Name: "BulkUpdateEvents", Resource: "events", Headers: idempotencyHeaders(idempotencyKey)})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// BulkDeleteScripts deletes many scripts, batching them against
+// DELETE /api/v1/scripts/bulk when the server supports it and otherwise
+// falling back to one DELETE call per script. The result's Succeeded
+// slice holds the IDs that were deleted.
+func (c *Client) BulkDeleteScripts(ctx context.Context, ids []string, opts BulkOptions) (*BulkResult[string], error) {
+	result := runBulkChunked(ctx, len(ids), opts,
+		func(ctx context.Context, start, end int) ([]string, error) {
+			return c.bulkDeleteScriptsChunk(ctx, ids[start:end], opts.idempotencyKey(start))
+		},
+		func(ctx context.Context, i int) (string, error) {
+			id := ids[i]
+			if id == "" {
+				return "", fmt.Errorf("script id is required")
+			}
+			path := c.baseURL + apiPathPrefix + "/scripts/" + pathSeg(id)
+			err := c.do(ctx, http.MethodDelete, path, nil, []int{http.StatusOK}, nil, "failed to delete script",
+				opInfo{Name: "DeleteScript", Resource: "scripts", ResourceID: id, Headers: idempotencyHeaders(opts.idempotencyKey(i))})
+			return id, err
+		},
+	)
+	return result, nil
+}
+
+// bulkDeleteScriptsChunk submits one chunk to the bulk-delete endpoint.
+func (c *Client) bulkDeleteScriptsChunk(ctx context.Context, ids []string, idempotencyKey string) ([]string, error) {
+	path := c.baseURL + apiPathPrefix + "/scripts/bulk"
+	raw, _ := json.Marshal(map[string][]string{"ids": ids})
+	var resp struct {
+		Success bool     `json:"success"`
+		Message string   `json:"message"`
+		Status  int      `json:"status"`
+		Data    []string `json:"data"`
+	}
+	err := c.do(ctx, http.MethodDelete, path, raw, []int{http.StatusOK}, &resp, "failed to bulk delete scripts",
+		opInfo{Name: "BulkDeleteScripts", Resource: "scripts", Headers: idempotencyHeaders(idempotencyKey)})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+func idempotencyHeaders(key string) http.Header {
+	if key == "" {
+		return nil
+	}
+	return http.Header{"Idempotency-Key": []string{key}}
+}
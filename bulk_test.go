@@ -0,0 +1,189 @@
+package processorsdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestBulkCreateScripts_FallbackMergesWithEarlierChunks reproduces the
+// review report: two chunks of 2 items each, the first chunk succeeds via
+// the bulk endpoint, the second 404s and must fall back to per-item calls
+// for only its own items, merged with the first chunk's results.
+func TestBulkCreateScripts_FallbackMergesWithEarlierChunks(t *testing.T) {
+	var bulkCalls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/scripts/bulk":
+			bulkCalls++
+			if bulkCalls == 1 {
+				var bodies []CreateScriptBody
+				json.NewDecoder(r.Body).Decode(&bodies)
+				items := make([]ScriptItem, len(bodies))
+				for i, b := range bodies {
+					items[i] = ScriptItem{ID: fmt.Sprintf("bulk-%d", i), Name: b.Name}
+				}
+				writeJSON(w, http.StatusCreated, ListScriptsResponse{Data: items})
+				return
+			}
+			writeJSON(w, http.StatusNotFound, map[string]string{"message": "not found"})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/scripts":
+			var body CreateScriptBody
+			json.NewDecoder(r.Body).Decode(&body)
+			writeJSON(w, http.StatusCreated, GetScriptResponse{Data: ScriptItem{ID: "single-" + body.Name, Name: body.Name}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	bodies := []CreateScriptBody{
+		{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"},
+	}
+	result, err := client.BulkCreateScripts(context.Background(), bodies, BulkOptions{ChunkSize: 2})
+	if err != nil {
+		t.Fatalf("BulkCreateScripts: %v", err)
+	}
+
+	if len(result.Failed) != 0 {
+		t.Fatalf("Failed = %v, want none", result.Failed)
+	}
+	if len(result.Succeeded) != 4 {
+		t.Fatalf("Succeeded has %d items, want 4 (2 from bulk chunk 1 + 2 from per-item fallback), got %+v", len(result.Succeeded), result.Succeeded)
+	}
+
+	var names []string
+	for _, s := range result.Succeeded {
+		names = append(names, s.Name)
+	}
+	for _, want := range []string{"a", "b", "c", "d"} {
+		found := false
+		for _, n := range names {
+			if n == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("Succeeded = %v, missing %q", names, want)
+		}
+	}
+}
+
+// TestBulkUpdateEvents_FallsBackToPerItemOn404 exercises the
+// bulk-endpoint-then-fallback path for BulkUpdateEvents, which shares
+// runBulkChunked with BulkCreateScripts.
+func TestBulkUpdateEvents_FallsBackToPerItemOn404(t *testing.T) {
+	var putCalls []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v1/events/bulk":
+			writeJSON(w, http.StatusNotFound, map[string]string{"message": "not found"})
+		case r.Method == http.MethodPut:
+			putCalls = append(putCalls, r.URL.Path)
+			writeJSON(w, http.StatusOK, GetEventResponse{Data: EventItem{ID: "evt"}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	updates := []BulkEventUpdate{{ID: "a"}, {ID: "b"}}
+	result, err := client.BulkUpdateEvents(context.Background(), updates, BulkOptions{})
+	if err != nil {
+		t.Fatalf("BulkUpdateEvents: %v", err)
+	}
+	if len(result.Succeeded) != 2 {
+		t.Fatalf("Succeeded = %+v, want 2 items via per-item fallback", result.Succeeded)
+	}
+	if len(putCalls) != 2 {
+		t.Fatalf("PUT calls = %v, want one per event", putCalls)
+	}
+}
+
+// TestBulkDeleteScripts_UsesBulkEndpointWhenSupported exercises the
+// bulk-endpoint-succeeds path for BulkDeleteScripts (no fallback needed).
+func TestBulkDeleteScripts_UsesBulkEndpointWhenSupported(t *testing.T) {
+	var deleteCalls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete && r.URL.Path == "/api/v1/scripts/bulk" {
+			deleteCalls++
+			var body struct {
+				IDs []string `json:"ids"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": body.IDs})
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	result, err := client.BulkDeleteScripts(context.Background(), []string{"a", "b", "c"}, BulkOptions{})
+	if err != nil {
+		t.Fatalf("BulkDeleteScripts: %v", err)
+	}
+	if deleteCalls != 1 {
+		t.Fatalf("bulk delete endpoint called %d times, want 1 (no per-item fallback expected)", deleteCalls)
+	}
+	if len(result.Succeeded) != 3 {
+		t.Fatalf("Succeeded = %v, want all 3 ids", result.Succeeded)
+	}
+}
+
+func TestRunBulkPerItem_ContinueOnError(t *testing.T) {
+	result := runBulkPerItem(context.Background(), 4, BulkOptions{ContinueOnError: true}, func(ctx context.Context, i int) (int, error) {
+		if i == 2 {
+			return 0, fmt.Errorf("boom")
+		}
+		return i, nil
+	})
+	if len(result.Failed) != 1 || result.Failed[0].Index != 2 {
+		t.Fatalf("Failed = %+v, want one failure at index 2", result.Failed)
+	}
+	if len(result.Succeeded) != 3 {
+		t.Fatalf("Succeeded = %v, want 3 items", result.Succeeded)
+	}
+}
+
+func TestRunBulkPerItem_StopsOnFirstErrorByDefault(t *testing.T) {
+	result := runBulkPerItem(context.Background(), 4, BulkOptions{Concurrency: 1}, func(ctx context.Context, i int) (int, error) {
+		if i == 1 {
+			return 0, fmt.Errorf("boom")
+		}
+		return i, nil
+	})
+	if len(result.Succeeded)+len(result.Failed) != 2 {
+		t.Fatalf("got %d succeeded, %d failed, want 2 outcomes (items 0 and 1; nothing launched after the failure)", len(result.Succeeded), len(result.Failed))
+	}
+	if len(result.Failed) != 1 {
+		t.Fatalf("Failed = %+v, want exactly the one failing item", result.Failed)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -11,23 +11,78 @@ import (
 	"net/url"
 	"strings"
 	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
 	apiPathPrefix  = "/api/v1"
 	defaultTimeout = 10 * time.Second
+	tracerName     = "github.com/kerimovok/processor-service-sdk-go"
 )
 
 // Config holds configuration for the processor service client
 type Config struct {
 	BaseURL string        // Processor service base URL (e.g. "http://localhost:3003")
 	Timeout time.Duration // Request timeout (default 10 seconds)
+
+	// MaxRetries is the number of retry attempts made after a retryable
+	// failure (0 disables retries, the default).
+	MaxRetries int
+	// RetryBaseDelay is the upper bound of the jittered delay before the
+	// first retry; the bound doubles for each subsequent retry, and the
+	// actual delay is drawn at random between zero and the bound.
+	// Defaults to defaultRetryBaseDelay.
+	RetryBaseDelay time.Duration
+	// RetryMaxDelay caps the backoff delay between retries. Defaults to
+	// defaultRetryMaxDelay.
+	RetryMaxDelay time.Duration
+	// RetryableStatuses overrides the set of HTTP status codes that are
+	// retried. Defaults to defaultRetryableStatuses (429, 500, 502, 503
+	// and 504).
+	RetryableStatuses []int
+	// RetryClassifier, if set, decides whether a given response/error pair
+	// should be retried, overriding RetryableStatuses for non-nil
+	// responses.
+	RetryClassifier func(*http.Response, error) bool
+
+	// HTTPClient, if set, is copied and the copy is used instead of a
+	// default *http.Client. If the supplied client's Timeout is zero, the
+	// copy gets Timeout (or the 10 second default). Middlewares still wrap
+	// its Transport (or http.DefaultTransport if it is nil).
+	HTTPClient *http.Client
+	// Middlewares wrap the client's http.RoundTripper, outermost first,
+	// letting callers attach auth, headers, tracing or custom transports.
+	Middlewares []RoundTripperMiddleware
+
+	// Tracer, if set, is used to create a span named
+	// "processor-sdk.<Operation>" around every outbound call made through
+	// Client.do. The SSE connections opened by StreamScriptExecutions and
+	// StreamEvents are not traced.
+	Tracer trace.TracerProvider
+	// Metrics, if set, is notified of the outcome of every outbound call.
+	Metrics Metrics
 }
 
 // Client is the processor service HTTP client
 type Client struct {
 	baseURL string
 	client  *http.Client
+
+	maxRetries        int
+	retryBaseDelay    time.Duration
+	retryMaxDelay     time.Duration
+	retryableStatuses []int
+	retryClassifier   func(*http.Response, error) bool
+
+	tracer  trace.Tracer
+	metrics Metrics
+
+	validators map[string]Validator
 }
 
 // APIError represents an error returned by the processor service API
@@ -35,13 +90,27 @@ type APIError struct {
 	StatusCode int
 	Message    string
 	Body       string
+	// Attempts is the number of attempts made for the request that
+	// produced this error (1 means no retries occurred).
+	Attempts int
+	// RequestID is the X-Request-ID of the failed request, populated when
+	// WithRequestID() is among the client's middlewares.
+	RequestID string
 }
 
 func (e *APIError) Error() string {
-	if e.Message != "" {
-		return fmt.Sprintf("processor service returned status %d: %s", e.StatusCode, e.Message)
+	msg := e.Message
+	if msg == "" {
+		msg = e.Body
 	}
-	return fmt.Sprintf("processor service returned status %d: %s", e.StatusCode, e.Body)
+	suffix := ""
+	if e.Attempts > 1 {
+		suffix = fmt.Sprintf(" after %d attempts", e.Attempts)
+	}
+	if e.RequestID != "" {
+		return fmt.Sprintf("processor service returned status %d%s (request %s): %s", e.StatusCode, suffix, e.RequestID, msg)
+	}
+	return fmt.Sprintf("processor service returned status %d%s: %s", e.StatusCode, suffix, msg)
 }
 
 // IsAPIError checks if an error is an APIError and returns it
@@ -53,7 +122,7 @@ func IsAPIError(err error) (*APIError, bool) {
 	return nil, false
 }
 
-func parseErrorResponse(statusCode int, body []byte) *APIError {
+func parseErrorResponse(statusCode int, body []byte, attempts int, requestID string) *APIError {
 	var errorResp struct {
 		Message string `json:"message"`
 		Success bool   `json:"success"`
@@ -66,9 +135,9 @@ func parseErrorResponse(statusCode int, body []byte) *APIError {
 		if errMessage == "" {
 			errMessage = errorResp.Message
 		}
-		return &APIError{StatusCode: statusCode, Message: errMessage, Body: bodyStr}
+		return &APIError{StatusCode: statusCode, Message: errMessage, Body: bodyStr, Attempts: attempts, RequestID: requestID}
 	}
-	return &APIError{StatusCode: statusCode, Message: bodyStr, Body: bodyStr}
+	return &APIError{StatusCode: statusCode, Message: bodyStr, Body: bodyStr, Attempts: attempts, RequestID: requestID}
 }
 
 func statusIn(code int, codes []int) bool {
@@ -82,37 +151,122 @@ func statusIn(code int, codes []int) bool {
 
 func pathSeg(s string) string { return url.PathEscape(s) }
 
-func (c *Client) do(ctx context.Context, method, path string, body []byte, successStatuses []int, result interface{}, wrapErr string) error {
-	var req *http.Request
-	var err error
-	if len(body) > 0 {
-		req, err = http.NewRequestWithContext(ctx, method, path, bytes.NewReader(body))
-	} else {
-		req, err = http.NewRequestWithContext(ctx, method, path, nil)
+// opInfo carries the per-call metadata needed for tracing and metrics.
+type opInfo struct {
+	Name       string      // e.g. "ListEvents"
+	Resource   string      // e.g. "events", "scripts", "script-executions"
+	ResourceID string      // the resource's ID, when this call targets one
+	Headers    http.Header // extra headers to set on the request, if any
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body []byte, successStatuses []int, result interface{}, wrapErr string, op opInfo) error {
+	ctx, span := c.startSpan(ctx, method, path, op)
+	start := time.Now()
+	statusCode := 0
+	defer func() {
+		c.recordMetrics(op.Name, statusCode, time.Since(start))
+		c.endSpan(span, statusCode)
+	}()
+
+	attempts := 0
+
+	for {
+		attempts++
+
+		var req *http.Request
+		var err error
+		if len(body) > 0 {
+			req, err = http.NewRequestWithContext(ctx, method, path, bytes.NewReader(body))
+		} else {
+			req, err = http.NewRequestWithContext(ctx, method, path, nil)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", wrapErr, err)
+		}
+		if len(body) > 0 {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		for key, values := range op.Headers {
+			for _, v := range values {
+				req.Header.Set(key, v)
+			}
+		}
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("%s: %w", wrapErr, ctx.Err())
+			}
+			if !c.shouldRetry(attempts, resp, err) {
+				return fmt.Errorf("%s: %w", wrapErr, err)
+			}
+			if !c.waitForRetry(ctx, attempts, nil) {
+				return fmt.Errorf("%s: %w", wrapErr, ctx.Err())
+			}
+			continue
+		}
+		statusCode = resp.StatusCode
+
+		if !statusIn(resp.StatusCode, successStatuses) {
+			respBody, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+
+			if c.shouldRetry(attempts, resp, nil) {
+				if c.waitForRetry(ctx, attempts, resp) {
+					continue
+				}
+			}
+			return parseErrorResponse(resp.StatusCode, respBody, attempts, requestID(resp))
+		}
+
+		if result != nil {
+			err := json.NewDecoder(resp.Body).Decode(result)
+			resp.Body.Close()
+			if err != nil {
+				return fmt.Errorf("%s: %w", wrapErr, err)
+			}
+		} else {
+			resp.Body.Close()
+		}
+		return nil
 	}
-	if err != nil {
-		return fmt.Errorf("%s: %w", wrapErr, err)
+}
+
+func (c *Client) startSpan(ctx context.Context, method, path string, op opInfo) (context.Context, trace.Span) {
+	if c.tracer == nil {
+		return ctx, nil
 	}
-	if len(body) > 0 {
-		req.Header.Set("Content-Type", "application/json")
+	attrs := []attribute.KeyValue{
+		attribute.String("http.method", method),
+		attribute.String("http.url", path),
+		attribute.String("processor.resource", op.Resource),
 	}
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return fmt.Errorf("%s: %w", wrapErr, err)
+	if op.ResourceID != "" {
+		attrs = append(attrs, attribute.String("processor.id", op.ResourceID))
 	}
-	defer resp.Body.Close()
+	ctx, span := c.tracer.Start(ctx, "processor-sdk."+op.Name, trace.WithAttributes(attrs...))
+	return ctx, span
+}
 
-	if !statusIn(resp.StatusCode, successStatuses) {
-		respBody, _ := io.ReadAll(resp.Body)
-		return parseErrorResponse(resp.StatusCode, respBody)
+func (c *Client) endSpan(span trace.Span, statusCode int) {
+	if span == nil {
+		return
+	}
+	if statusCode != 0 {
+		span.SetAttributes(attribute.Int("http.status_code", statusCode))
 	}
+	if statusCode >= 400 {
+		span.SetStatus(codes.Error, fmt.Sprintf("status %d", statusCode))
+	}
+	span.End()
+}
 
-	if result != nil {
-		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
-			return fmt.Errorf("%s: %w", wrapErr, err)
-		}
+func (c *Client) recordMetrics(op string, statusCode int, dur time.Duration) {
+	if c.metrics == nil {
+		return
 	}
-	return nil
+	c.metrics.ObserveRequest(op, statusCode, dur)
 }
 
 // NewClient creates a new processor service client
@@ -125,9 +279,54 @@ func NewClient(config Config) (*Client, error) {
 	if timeout == 0 {
 		timeout = defaultTimeout
 	}
+
+	retryBaseDelay := config.RetryBaseDelay
+	if retryBaseDelay == 0 {
+		retryBaseDelay = defaultRetryBaseDelay
+	}
+	retryMaxDelay := config.RetryMaxDelay
+	if retryMaxDelay == 0 {
+		retryMaxDelay = defaultRetryMaxDelay
+	}
+	retryableStatuses := config.RetryableStatuses
+	if retryableStatuses == nil {
+		retryableStatuses = defaultRetryableStatuses
+	}
+
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	} else {
+		clone := *httpClient
+		httpClient = &clone
+	}
+	if httpClient.Timeout == 0 {
+		httpClient.Timeout = timeout
+	}
+	transport := httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(config.Middlewares) - 1; i >= 0; i-- {
+		transport = config.Middlewares[i](transport)
+	}
+	httpClient.Transport = transport
+
+	var tracer trace.Tracer
+	if config.Tracer != nil {
+		tracer = config.Tracer.Tracer(tracerName)
+	}
+
 	return &Client{
-		baseURL: baseURL,
-		client:  &http.Client{Timeout: timeout},
+		baseURL:           baseURL,
+		client:            httpClient,
+		maxRetries:        config.MaxRetries,
+		retryBaseDelay:    retryBaseDelay,
+		retryMaxDelay:     retryMaxDelay,
+		retryableStatuses: retryableStatuses,
+		retryClassifier:   config.RetryClassifier,
+		tracer:            tracer,
+		metrics:           config.Metrics,
 	}, nil
 }
 
@@ -177,7 +376,7 @@ func (c *Client) ListEvents(ctx context.Context, queryString string) (*ListEvent
 		path += "?" + queryString
 	}
 	var result ListEventsResponse
-	err := c.do(ctx, http.MethodGet, path, nil, []int{http.StatusOK}, &result, "failed to list events")
+	err := c.do(ctx, http.MethodGet, path, nil, []int{http.StatusOK}, &result, "failed to list events", opInfo{Name: "ListEvents", Resource: "events"})
 	if err != nil {
 		return nil, err
 	}
@@ -191,7 +390,7 @@ func (c *Client) GetEvent(ctx context.Context, id string) (*GetEventResponse, er
 	}
 	path := c.baseURL + apiPathPrefix + "/events/" + pathSeg(id)
 	var result GetEventResponse
-	err := c.do(ctx, http.MethodGet, path, nil, []int{http.StatusOK}, &result, "failed to get event")
+	err := c.do(ctx, http.MethodGet, path, nil, []int{http.StatusOK}, &result, "failed to get event", opInfo{Name: "GetEvent", Resource: "events", ResourceID: id})
 	if err != nil {
 		return nil, err
 	}
@@ -206,7 +405,7 @@ func (c *Client) UpdateEvent(ctx context.Context, id string, payload map[string]
 	path := c.baseURL + apiPathPrefix + "/events/" + pathSeg(id)
 	body, _ := json.Marshal(map[string]interface{}{"payload": payload})
 	var result GetEventResponse
-	err := c.do(ctx, http.MethodPut, path, body, []int{http.StatusOK}, &result, "failed to update event")
+	err := c.do(ctx, http.MethodPut, path, body, []int{http.StatusOK}, &result, "failed to update event", opInfo{Name: "UpdateEvent", Resource: "events", ResourceID: id})
 	if err != nil {
 		return nil, err
 	}
@@ -219,7 +418,7 @@ func (c *Client) DeleteEvent(ctx context.Context, id string) error {
 		return fmt.Errorf("event id is required")
 	}
 	path := c.baseURL + apiPathPrefix + "/events/" + pathSeg(id)
-	return c.do(ctx, http.MethodDelete, path, nil, []int{http.StatusOK}, nil, "failed to delete event")
+	return c.do(ctx, http.MethodDelete, path, nil, []int{http.StatusOK}, nil, "failed to delete event", opInfo{Name: "DeleteEvent", Resource: "events", ResourceID: id})
 }
 
 // ScriptItem represents a script in list/detail responses
@@ -279,7 +478,7 @@ func (c *Client) ListScripts(ctx context.Context, queryString string) (*ListScri
 		path += "?" + queryString
 	}
 	var result ListScriptsResponse
-	err := c.do(ctx, http.MethodGet, path, nil, []int{http.StatusOK}, &result, "failed to list scripts")
+	err := c.do(ctx, http.MethodGet, path, nil, []int{http.StatusOK}, &result, "failed to list scripts", opInfo{Name: "ListScripts", Resource: "scripts"})
 	if err != nil {
 		return nil, err
 	}
@@ -293,7 +492,7 @@ func (c *Client) GetScript(ctx context.Context, id string) (*GetScriptResponse,
 	}
 	path := c.baseURL + apiPathPrefix + "/scripts/" + pathSeg(id)
 	var result GetScriptResponse
-	err := c.do(ctx, http.MethodGet, path, nil, []int{http.StatusOK}, &result, "failed to get script")
+	err := c.do(ctx, http.MethodGet, path, nil, []int{http.StatusOK}, &result, "failed to get script", opInfo{Name: "GetScript", Resource: "scripts", ResourceID: id})
 	if err != nil {
 		return nil, err
 	}
@@ -305,7 +504,7 @@ func (c *Client) CreateScript(ctx context.Context, body CreateScriptBody) (*GetS
 	path := c.baseURL + apiPathPrefix + "/scripts"
 	raw, _ := json.Marshal(body)
 	var result GetScriptResponse
-	err := c.do(ctx, http.MethodPost, path, raw, []int{http.StatusCreated}, &result, "failed to create script")
+	err := c.do(ctx, http.MethodPost, path, raw, []int{http.StatusCreated}, &result, "failed to create script", opInfo{Name: "CreateScript", Resource: "scripts"})
 	if err != nil {
 		return nil, err
 	}
@@ -320,7 +519,7 @@ func (c *Client) UpdateScript(ctx context.Context, id string, body UpdateScriptB
 	path := c.baseURL + apiPathPrefix + "/scripts/" + pathSeg(id)
 	raw, _ := json.Marshal(body)
 	var result GetScriptResponse
-	err := c.do(ctx, http.MethodPut, path, raw, []int{http.StatusOK}, &result, "failed to update script")
+	err := c.do(ctx, http.MethodPut, path, raw, []int{http.StatusOK}, &result, "failed to update script", opInfo{Name: "UpdateScript", Resource: "scripts", ResourceID: id})
 	if err != nil {
 		return nil, err
 	}
@@ -333,7 +532,7 @@ func (c *Client) DeleteScript(ctx context.Context, id string) error {
 		return fmt.Errorf("script id is required")
 	}
 	path := c.baseURL + apiPathPrefix + "/scripts/" + pathSeg(id)
-	return c.do(ctx, http.MethodDelete, path, nil, []int{http.StatusOK}, nil, "failed to delete script")
+	return c.do(ctx, http.MethodDelete, path, nil, []int{http.StatusOK}, nil, "failed to delete script", opInfo{Name: "DeleteScript", Resource: "scripts", ResourceID: id})
 }
 
 // ListScriptExecutionsByScriptID lists script executions for a script
@@ -346,7 +545,7 @@ func (c *Client) ListScriptExecutionsByScriptID(ctx context.Context, scriptID st
 		path += "?" + queryString
 	}
 	var result ListScriptExecutionsResponse
-	err := c.do(ctx, http.MethodGet, path, nil, []int{http.StatusOK}, &result, "failed to list script executions")
+	err := c.do(ctx, http.MethodGet, path, nil, []int{http.StatusOK}, &result, "failed to list script executions", opInfo{Name: "ListScriptExecutionsByScriptID", Resource: "script-executions", ResourceID: scriptID})
 	if err != nil {
 		return nil, err
 	}
@@ -390,7 +589,7 @@ func (c *Client) ListScriptExecutions(ctx context.Context, queryString string) (
 		path += "?" + queryString
 	}
 	var result ListScriptExecutionsResponse
-	err := c.do(ctx, http.MethodGet, path, nil, []int{http.StatusOK}, &result, "failed to list script executions")
+	err := c.do(ctx, http.MethodGet, path, nil, []int{http.StatusOK}, &result, "failed to list script executions", opInfo{Name: "ListScriptExecutions", Resource: "script-executions"})
 	if err != nil {
 		return nil, err
 	}
@@ -404,7 +603,7 @@ func (c *Client) GetScriptExecution(ctx context.Context, id string) (*GetScriptE
 	}
 	path := c.baseURL + apiPathPrefix + "/script-executions/" + pathSeg(id)
 	var result GetScriptExecutionResponse
-	err := c.do(ctx, http.MethodGet, path, nil, []int{http.StatusOK}, &result, "failed to get script execution")
+	err := c.do(ctx, http.MethodGet, path, nil, []int{http.StatusOK}, &result, "failed to get script execution", opInfo{Name: "GetScriptExecution", Resource: "script-executions", ResourceID: id})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,99 @@
+package processorsdk
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+// RoundTripperMiddleware wraps an http.RoundTripper to add cross-cutting
+// behavior (auth, headers, tracing, ...). Middlewares are applied
+// outermost-first, i.e. Config.Middlewares[0] sees the request before
+// Config.Middlewares[1].
+type RoundTripperMiddleware func(http.RoundTripper) http.RoundTripper
+
+// roundTripperFunc adapts a function to the http.RoundTripper interface.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+const requestIDHeader = "X-Request-ID"
+
+// requestID extracts the X-Request-ID of the request that produced resp,
+// if one was set (typically by WithRequestID()).
+func requestID(resp *http.Response) string {
+	if resp == nil || resp.Request == nil {
+		return ""
+	}
+	return resp.Request.Header.Get(requestIDHeader)
+}
+
+// WithBearerToken returns a middleware that calls tokenFunc for every
+// request and sets the resulting value as a "Bearer" Authorization header.
+func WithBearerToken(tokenFunc func(ctx context.Context) (string, error)) RoundTripperMiddleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			token, err := tokenFunc(req.Context())
+			if err != nil {
+				return nil, fmt.Errorf("failed to obtain bearer token: %w", err)
+			}
+			req.Header.Set("Authorization", "Bearer "+token)
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// WithStaticHeaders returns a middleware that sets headers on every
+// request, without overwriting headers the caller already set.
+func WithStaticHeaders(headers http.Header) RoundTripperMiddleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			for key, values := range headers {
+				if req.Header.Get(key) != "" {
+					continue
+				}
+				for _, v := range values {
+					req.Header.Add(key, v)
+				}
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// WithBasicAuth returns a middleware that sets HTTP Basic authentication
+// credentials on every request.
+func WithBasicAuth(username, password string) RoundTripperMiddleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req.SetBasicAuth(username, password)
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// WithRequestID returns a middleware that stamps every request with a
+// random X-Request-ID header (unless the caller already set one), so it
+// can later be read off APIError.RequestID.
+func WithRequestID() RoundTripperMiddleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(requestIDHeader) == "" {
+				req.Header.Set(requestIDHeader, newRequestID())
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// newRequestID generates a random UUIDv4 string.
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
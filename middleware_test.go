@@ -0,0 +1,70 @@
+package processorsdk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestWithStaticHeaders_DoesNotOverwriteCallerSetHeader(t *testing.T) {
+	mw := WithStaticHeaders(http.Header{"X-Env": []string{"static"}, "X-Extra": []string{"v1", "v2"}})
+
+	var captured http.Header
+	next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		captured = req.Header
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	req.Header.Set("X-Env", "caller")
+
+	if _, err := mw(next).RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+
+	if got := captured.Get("X-Env"); got != "caller" {
+		t.Fatalf("X-Env = %q, want caller-set value preserved (\"caller\")", got)
+	}
+	if got := captured.Values("X-Extra"); len(got) != 2 || got[0] != "v1" || got[1] != "v2" {
+		t.Fatalf("X-Extra = %v, want [v1 v2] applied since the caller never set it", got)
+	}
+}
+
+func TestWithBearerToken_SetsAuthorizationHeader(t *testing.T) {
+	mw := WithBearerToken(func(ctx context.Context) (string, error) {
+		return "tok123", nil
+	})
+
+	var captured string
+	next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		captured = req.Header.Get("Authorization")
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	if _, err := mw(next).RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	if captured != "Bearer tok123" {
+		t.Fatalf("Authorization = %q, want %q", captured, "Bearer tok123")
+	}
+}
+
+func TestWithRequestID_DoesNotOverwriteCallerSetID(t *testing.T) {
+	mw := WithRequestID()
+
+	var captured string
+	next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		captured = req.Header.Get(requestIDHeader)
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	req.Header.Set(requestIDHeader, "caller-id")
+	if _, err := mw(next).RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	if captured != "caller-id" {
+		t.Fatalf("%s = %q, want caller-set value preserved", requestIDHeader, captured)
+	}
+}
@@ -0,0 +1,285 @@
+package processorsdk
+
+import (
+	"context"
+	"iter"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// queryFilter is a single `filter[field][op]=value` clause.
+type queryFilter struct {
+	field string
+	op    string
+	value string
+}
+
+// baseListQuery holds the pagination, sorting and filter fields shared by
+// every typed list query builder.
+type baseListQuery struct {
+	page          int
+	perPage       int
+	sortBy        string
+	sortDir       string
+	createdAfter  *time.Time
+	createdBefore *time.Time
+	filters       []queryFilter
+}
+
+// setPage sets the requested page number (1-indexed).
+func (b *baseListQuery) setPage(page int) { b.page = page }
+
+// setPerPage sets the number of items per page.
+func (b *baseListQuery) setPerPage(perPage int) { b.perPage = perPage }
+
+// setSortBy sets the field to sort by.
+func (b *baseListQuery) setSortBy(field string) { b.sortBy = field }
+
+// setSortDir sets the sort direction ("asc" or "desc").
+func (b *baseListQuery) setSortDir(dir string) { b.sortDir = dir }
+
+// setCreatedAfter restricts results to items created after t.
+func (b *baseListQuery) setCreatedAfter(t time.Time) { b.createdAfter = &t }
+
+// setCreatedBefore restricts results to items created before t.
+func (b *baseListQuery) setCreatedBefore(t time.Time) { b.createdBefore = &t }
+
+// filter adds a `filter[field][op]=value` clause to the query.
+func (b *baseListQuery) filter(field, op, value string) {
+	b.filters = append(b.filters, queryFilter{field: field, op: op, value: value})
+}
+
+// encode renders the query as a canonical query string.
+func (b *baseListQuery) encode() string {
+	q := url.Values{}
+	if b.page > 0 {
+		q.Set("page", strconv.Itoa(b.page))
+	}
+	if b.perPage > 0 {
+		q.Set("perPage", strconv.Itoa(b.perPage))
+	}
+	if b.sortBy != "" {
+		q.Set("sortBy", b.sortBy)
+	}
+	if b.sortDir != "" {
+		q.Set("sortDir", b.sortDir)
+	}
+	if b.createdAfter != nil {
+		q.Set("createdAfter", b.createdAfter.UTC().Format(time.RFC3339))
+	}
+	if b.createdBefore != nil {
+		q.Set("createdBefore", b.createdBefore.UTC().Format(time.RFC3339))
+	}
+	for _, f := range b.filters {
+		q.Set("filter["+f.field+"]["+f.op+"]", f.value)
+	}
+	return q.Encode()
+}
+
+// EventListQuery is a typed query builder for ListEventsQ.
+type EventListQuery struct {
+	baseListQuery
+	Service string
+	Type    string
+}
+
+func (q *EventListQuery) Page(page int) *EventListQuery             { q.setPage(page); return q }
+func (q *EventListQuery) PerPage(perPage int) *EventListQuery       { q.setPerPage(perPage); return q }
+func (q *EventListQuery) SortBy(field string) *EventListQuery       { q.setSortBy(field); return q }
+func (q *EventListQuery) SortDir(dir string) *EventListQuery        { q.setSortDir(dir); return q }
+func (q *EventListQuery) CreatedAfter(t time.Time) *EventListQuery  { q.setCreatedAfter(t); return q }
+func (q *EventListQuery) CreatedBefore(t time.Time) *EventListQuery { q.setCreatedBefore(t); return q }
+func (q *EventListQuery) Filter(field, op, value string) *EventListQuery {
+	q.filter(field, op, value)
+	return q
+}
+
+func (q *EventListQuery) encodeAll() string {
+	v, _ := url.ParseQuery(q.encode())
+	if q.Service != "" {
+		v.Set("service", q.Service)
+	}
+	if q.Type != "" {
+		v.Set("type", q.Type)
+	}
+	return v.Encode()
+}
+
+// ScriptListQuery is a typed query builder for ListScriptsQ.
+type ScriptListQuery struct {
+	baseListQuery
+	Service string
+	Type    string
+}
+
+func (q *ScriptListQuery) Page(page int) *ScriptListQuery            { q.setPage(page); return q }
+func (q *ScriptListQuery) PerPage(perPage int) *ScriptListQuery      { q.setPerPage(perPage); return q }
+func (q *ScriptListQuery) SortBy(field string) *ScriptListQuery      { q.setSortBy(field); return q }
+func (q *ScriptListQuery) SortDir(dir string) *ScriptListQuery       { q.setSortDir(dir); return q }
+func (q *ScriptListQuery) CreatedAfter(t time.Time) *ScriptListQuery { q.setCreatedAfter(t); return q }
+func (q *ScriptListQuery) CreatedBefore(t time.Time) *ScriptListQuery {
+	q.setCreatedBefore(t)
+	return q
+}
+func (q *ScriptListQuery) Filter(field, op, value string) *ScriptListQuery {
+	q.filter(field, op, value)
+	return q
+}
+
+func (q *ScriptListQuery) encodeAll() string {
+	v, _ := url.ParseQuery(q.encode())
+	if q.Service != "" {
+		v.Set("service", q.Service)
+	}
+	if q.Type != "" {
+		v.Set("type", q.Type)
+	}
+	return v.Encode()
+}
+
+// ScriptExecutionListQuery is a typed query builder for
+// ListScriptExecutionsQ and ListScriptExecutionsByScriptIDQ.
+type ScriptExecutionListQuery struct {
+	baseListQuery
+	Status string
+}
+
+func (q *ScriptExecutionListQuery) Page(page int) *ScriptExecutionListQuery {
+	q.setPage(page)
+	return q
+}
+func (q *ScriptExecutionListQuery) PerPage(perPage int) *ScriptExecutionListQuery {
+	q.setPerPage(perPage)
+	return q
+}
+func (q *ScriptExecutionListQuery) SortBy(field string) *ScriptExecutionListQuery {
+	q.setSortBy(field)
+	return q
+}
+func (q *ScriptExecutionListQuery) SortDir(dir string) *ScriptExecutionListQuery {
+	q.setSortDir(dir)
+	return q
+}
+func (q *ScriptExecutionListQuery) CreatedAfter(t time.Time) *ScriptExecutionListQuery {
+	q.setCreatedAfter(t)
+	return q
+}
+func (q *ScriptExecutionListQuery) CreatedBefore(t time.Time) *ScriptExecutionListQuery {
+	q.setCreatedBefore(t)
+	return q
+}
+func (q *ScriptExecutionListQuery) Filter(field, op, value string) *ScriptExecutionListQuery {
+	q.filter(field, op, value)
+	return q
+}
+
+func (q *ScriptExecutionListQuery) encodeAll() string {
+	v, _ := url.ParseQuery(q.encode())
+	if q.Status != "" {
+		v.Set("status", q.Status)
+	}
+	return v.Encode()
+}
+
+// ListEventsQ lists events using a typed query builder instead of a raw
+// query string.
+func (c *Client) ListEventsQ(ctx context.Context, query EventListQuery) (*ListEventsResponse, error) {
+	return c.ListEvents(ctx, query.encodeAll())
+}
+
+// ListScriptsQ lists scripts using a typed query builder instead of a raw
+// query string.
+func (c *Client) ListScriptsQ(ctx context.Context, query ScriptListQuery) (*ListScriptsResponse, error) {
+	return c.ListScripts(ctx, query.encodeAll())
+}
+
+// ListScriptExecutionsQ lists script executions using a typed query
+// builder instead of a raw query string.
+func (c *Client) ListScriptExecutionsQ(ctx context.Context, query ScriptExecutionListQuery) (*ListScriptExecutionsResponse, error) {
+	return c.ListScriptExecutions(ctx, query.encodeAll())
+}
+
+// ListScriptExecutionsByScriptIDQ lists script executions for a script
+// using a typed query builder instead of a raw query string.
+func (c *Client) ListScriptExecutionsByScriptIDQ(ctx context.Context, scriptID string, query ScriptExecutionListQuery) (*ListScriptExecutionsResponse, error) {
+	return c.ListScriptExecutionsByScriptID(ctx, scriptID, query.encodeAll())
+}
+
+// IterateEvents walks every page of ListEventsQ starting from query,
+// yielding one EventItem at a time and transparently following
+// Pagination.NextPage. Iteration stops at the first error, which is
+// yielded as the second value.
+func (c *Client) IterateEvents(ctx context.Context, query EventListQuery) iter.Seq2[EventItem, error] {
+	return func(yield func(EventItem, error) bool) {
+		q := query
+		for {
+			resp, err := c.ListEventsQ(ctx, q)
+			if err != nil {
+				yield(EventItem{}, err)
+				return
+			}
+			for _, item := range resp.Data {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if resp.Pagination == nil || resp.Pagination.NextPage == nil {
+				return
+			}
+			q.Page(*resp.Pagination.NextPage)
+		}
+	}
+}
+
+// IterateScripts walks every page of ListScriptsQ starting from query,
+// yielding one ScriptItem at a time and transparently following
+// Pagination.NextPage. Iteration stops at the first error, which is
+// yielded as the second value.
+func (c *Client) IterateScripts(ctx context.Context, query ScriptListQuery) iter.Seq2[ScriptItem, error] {
+	return func(yield func(ScriptItem, error) bool) {
+		q := query
+		for {
+			resp, err := c.ListScriptsQ(ctx, q)
+			if err != nil {
+				yield(ScriptItem{}, err)
+				return
+			}
+			for _, item := range resp.Data {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if resp.Pagination == nil || resp.Pagination.NextPage == nil {
+				return
+			}
+			q.Page(*resp.Pagination.NextPage)
+		}
+	}
+}
+
+// IterateScriptExecutions walks every page of ListScriptExecutionsQ
+// starting from query, yielding one ScriptExecutionItem at a time and
+// transparently following Pagination.NextPage. Iteration stops at the
+// first error, which is yielded as the second value.
+func (c *Client) IterateScriptExecutions(ctx context.Context, query ScriptExecutionListQuery) iter.Seq2[ScriptExecutionItem, error] {
+	return func(yield func(ScriptExecutionItem, error) bool) {
+		q := query
+		for {
+			resp, err := c.ListScriptExecutionsQ(ctx, q)
+			if err != nil {
+				yield(ScriptExecutionItem{}, err)
+				return
+			}
+			for _, item := range resp.Data {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if resp.Pagination == nil || resp.Pagination.NextPage == nil {
+				return
+			}
+			q.Page(*resp.Pagination.NextPage)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package processorsdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestIterateScripts_FollowsNextPage verifies that IterateScripts keeps
+// requesting pages as long as Pagination.NextPage is set, and stops once
+// it is nil.
+func TestIterateScripts_FollowsNextPage(t *testing.T) {
+	var pagesSeen []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pagesSeen = append(pagesSeen, page)
+		switch page {
+		case "", "1":
+			next := 2
+			writeJSON(w, http.StatusOK, ListScriptsResponse{
+				Data:       []ScriptItem{{ID: "s1"}, {ID: "s2"}},
+				Pagination: &Pagination{NextPage: &next},
+			})
+		case "2":
+			writeJSON(w, http.StatusOK, ListScriptsResponse{
+				Data:       []ScriptItem{{ID: "s3"}},
+				Pagination: &Pagination{},
+			})
+		default:
+			t.Fatalf("unexpected page %q requested", page)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	var ids []string
+	for item, err := range client.IterateScripts(context.Background(), ScriptListQuery{}) {
+		if err != nil {
+			t.Fatalf("IterateScripts: %v", err)
+		}
+		ids = append(ids, item.ID)
+	}
+
+	want := []string{"s1", "s2", "s3"}
+	if len(ids) != len(want) {
+		t.Fatalf("ids = %v, want %v", ids, want)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("ids = %v, want %v", ids, want)
+		}
+	}
+}
+
+// TestIterateScripts_StopsOnFirstError verifies that a request error is
+// yielded and iteration does not continue past it.
+func TestIterateScripts_StopsOnFirstError(t *testing.T) {
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"message": "boom"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL, MaxRetries: 0})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	var sawErr bool
+	var items int
+	for item, err := range client.IterateScripts(context.Background(), ScriptListQuery{}) {
+		if err != nil {
+			sawErr = true
+			break
+		}
+		items++
+		_ = item
+	}
+
+	if !sawErr {
+		t.Fatal("expected IterateScripts to yield an error")
+	}
+	if items != 0 {
+		t.Fatalf("items = %d, want 0 (no items before the error)", items)
+	}
+	if requests != 1 {
+		t.Fatalf("requests = %d, want 1 (no further pages fetched after an error)", requests)
+	}
+}
+
+// TestIterateEvents_StopsWhenConsumerBreaks verifies that the iterator
+// stops requesting further pages once the consuming range loop breaks.
+func TestIterateEvents_StopsWhenConsumerBreaks(t *testing.T) {
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		next := 2
+		writeJSON(w, http.StatusOK, ListEventsResponse{
+			Data:       []EventItem{{ID: "e1"}, {ID: "e2"}},
+			Pagination: &Pagination{NextPage: &next},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	var seen int
+	for _, err := range client.IterateEvents(context.Background(), EventListQuery{}) {
+		if err != nil {
+			t.Fatalf("IterateEvents: %v", err)
+		}
+		seen++
+		break
+	}
+
+	if seen != 1 {
+		t.Fatalf("seen = %d, want 1", seen)
+	}
+	if requests != 1 {
+		t.Fatalf("requests = %d, want 1 (iteration must stop once the consumer breaks, not fetch page 2)", requests)
+	}
+}
+
+// TestIterateScriptExecutions_FollowsNextPage exercises the third
+// iterator with the same pagination-following behavior as the others.
+func TestIterateScriptExecutions_FollowsNextPage(t *testing.T) {
+	var pagesSeen []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pagesSeen = append(pagesSeen, page)
+		if page == "" || page == "1" {
+			next := 2
+			writeJSON(w, http.StatusOK, ListScriptExecutionsResponse{
+				Data:       []ScriptExecutionItem{{ID: "x1"}},
+				Pagination: &Pagination{NextPage: &next},
+			})
+			return
+		}
+		writeJSON(w, http.StatusOK, ListScriptExecutionsResponse{
+			Data:       []ScriptExecutionItem{{ID: "x2"}},
+			Pagination: &Pagination{},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	var ids []string
+	for item, err := range client.IterateScriptExecutions(context.Background(), ScriptExecutionListQuery{}) {
+		if err != nil {
+			t.Fatalf("IterateScriptExecutions: %v", err)
+		}
+		ids = append(ids, item.ID)
+	}
+	if len(ids) != 2 || ids[0] != "x1" || ids[1] != "x2" {
+		t.Fatalf("ids = %v, want [x1 x2]", ids)
+	}
+	if len(pagesSeen) != 2 {
+		t.Fatalf("pages requested = %v, want 2", pagesSeen)
+	}
+}
@@ -0,0 +1,89 @@
+package processorsdk
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryBaseDelay = 200 * time.Millisecond
+	defaultRetryMaxDelay  = 5 * time.Second
+)
+
+// defaultRetryableStatuses is retried when no RetryableStatuses override is
+// configured: 429 (rate limited) and the 500, 502, 503 and 504 server
+// errors. Other 5xx statuses (e.g. 501) are not retried by default.
+var defaultRetryableStatuses = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// shouldRetry decides whether attempt number attempts (1-indexed, the
+// attempt that just failed) should be retried. resp is nil when err is a
+// transport-level error (connection refused, timeout, ...).
+func (c *Client) shouldRetry(attempts int, resp *http.Response, err error) bool {
+	if attempts > c.maxRetries {
+		return false
+	}
+	if c.retryClassifier != nil {
+		return c.retryClassifier(resp, err)
+	}
+	if resp == nil {
+		// Transport-level failure: always worth a retry up to maxRetries.
+		return err != nil
+	}
+	return statusIn(resp.StatusCode, c.retryableStatuses)
+}
+
+// waitForRetry blocks for the backoff delay appropriate to attempts,
+// honoring a Retry-After header on resp when present. It returns false if
+// ctx is cancelled before the delay elapses.
+func (c *Client) waitForRetry(ctx context.Context, attempts int, resp *http.Response) bool {
+	delay := c.backoffDelay(attempts)
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			delay = retryAfter
+		}
+	}
+	return sleepWithContext(ctx, delay)
+}
+
+// backoffDelay computes an exponential backoff delay with full jitter,
+// capped at retryMaxDelay.
+func (c *Client) backoffDelay(attempts int) time.Duration {
+	base := float64(c.retryBaseDelay)
+	delay := base * math.Pow(2, float64(attempts-1))
+	if max := float64(c.retryMaxDelay); delay > max {
+		delay = max
+	}
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+// parseRetryAfter parses a Retry-After header value, which may be either a
+// number of seconds or an HTTP-date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		delay := time.Until(t)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
@@ -0,0 +1,102 @@
+package processorsdk
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestShouldRetry(t *testing.T) {
+	c := &Client{
+		maxRetries:        3,
+		retryableStatuses: defaultRetryableStatuses,
+	}
+
+	cases := []struct {
+		name     string
+		attempts int
+		resp     *http.Response
+		err      error
+		want     bool
+	}{
+		{"transport error within budget", 1, nil, errTest, true},
+		{"transport error exhausted", 4, nil, errTest, false},
+		{"retryable status", 1, &http.Response{StatusCode: http.StatusServiceUnavailable}, nil, true},
+		{"non-retryable status", 1, &http.Response{StatusCode: http.StatusBadRequest}, nil, false},
+		{"retryable status exhausted", 4, &http.Response{StatusCode: http.StatusServiceUnavailable}, nil, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := c.shouldRetry(tc.attempts, tc.resp, tc.err)
+			if got != tc.want {
+				t.Fatalf("shouldRetry(%d, %v, %v) = %v, want %v", tc.attempts, tc.resp, tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestShouldRetry_CustomClassifier(t *testing.T) {
+	c := &Client{
+		maxRetries: 3,
+		retryClassifier: func(resp *http.Response, err error) bool {
+			return resp != nil && resp.StatusCode == http.StatusTeapot
+		},
+	}
+	if !c.shouldRetry(1, &http.Response{StatusCode: http.StatusTeapot}, nil) {
+		t.Fatal("expected custom classifier to allow retry on 418")
+	}
+	if c.shouldRetry(1, &http.Response{StatusCode: http.StatusServiceUnavailable}, nil) {
+		t.Fatal("expected custom classifier to override default retryable statuses")
+	}
+}
+
+func TestBackoffDelay_CapsAtMaxDelay(t *testing.T) {
+	c := &Client{
+		retryBaseDelay: 200 * time.Millisecond,
+		retryMaxDelay:  1 * time.Second,
+	}
+	for attempt := 1; attempt <= 10; attempt++ {
+		d := c.backoffDelay(attempt)
+		if d < 0 || d > c.retryMaxDelay {
+			t.Fatalf("backoffDelay(%d) = %v, want within [0, %v]", attempt, d, c.retryMaxDelay)
+		}
+	}
+}
+
+func TestParseRetryAfter_Seconds(t *testing.T) {
+	d, ok := parseRetryAfter("5")
+	if !ok || d != 5*time.Second {
+		t.Fatalf("parseRetryAfter(\"5\") = %v, %v, want 5s, true", d, ok)
+	}
+}
+
+func TestParseRetryAfter_Negative(t *testing.T) {
+	if _, ok := parseRetryAfter("-1"); ok {
+		t.Fatal("parseRetryAfter(\"-1\") should not be ok")
+	}
+}
+
+func TestParseRetryAfter_HTTPDate(t *testing.T) {
+	future := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	d, ok := parseRetryAfter(future)
+	if !ok {
+		t.Fatalf("parseRetryAfter(%q) not ok", future)
+	}
+	if d <= 0 || d > 11*time.Second {
+		t.Fatalf("parseRetryAfter(%q) = %v, want roughly 10s", future, d)
+	}
+}
+
+func TestParseRetryAfter_Empty(t *testing.T) {
+	if _, ok := parseRetryAfter(""); ok {
+		t.Fatal("parseRetryAfter(\"\") should not be ok")
+	}
+}
+
+func TestParseRetryAfter_Invalid(t *testing.T) {
+	if _, ok := parseRetryAfter("not-a-date"); ok {
+		t.Fatal("parseRetryAfter(\"not-a-date\") should not be ok")
+	}
+}
+
+var errTest = &APIError{StatusCode: 0, Message: "boom"}
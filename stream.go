@@ -0,0 +1,344 @@
+package processorsdk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ScriptExecutionEventKind identifies the kind of change an event on the
+// script-executions stream represents.
+type ScriptExecutionEventKind string
+
+const (
+	ScriptExecutionEventCreated   ScriptExecutionEventKind = "created"
+	ScriptExecutionEventUpdated   ScriptExecutionEventKind = "updated"
+	ScriptExecutionEventCompleted ScriptExecutionEventKind = "completed"
+	ScriptExecutionEventFailed    ScriptExecutionEventKind = "failed"
+)
+
+// ScriptExecutionEvent is a single message delivered over the
+// script-executions SSE stream.
+type ScriptExecutionEvent struct {
+	Kind string              `json:"event"`
+	Data ScriptExecutionItem `json:"data"`
+}
+
+// EventKind identifies the kind of change an event on the events stream
+// represents.
+type EventKind string
+
+const (
+	EventCreated EventKind = "created"
+	EventUpdated EventKind = "updated"
+	EventDeleted EventKind = "deleted"
+)
+
+// StreamEvent is a single message delivered over the events SSE stream.
+type StreamEvent struct {
+	Kind string    `json:"event"`
+	Data EventItem `json:"data"`
+}
+
+// defaultSSERetryDelay is the reconnect backoff used before the stream has
+// received a server "retry:" hint. It is a var (not a const) so tests can
+// shrink it.
+var defaultSSERetryDelay = 3 * time.Second
+
+// maxSSELineBytes bounds a single SSE line (e.g. one "data:" line). The
+// default bufio.Scanner limit (64KB) is too small for an EventItem with a
+// large Payload.
+const maxSSELineBytes = 10 * 1024 * 1024
+
+// sseReconnectable reports whether a failure to (re)connect to an SSE
+// stream is worth retrying. Transport-level errors (no status code), 429
+// and any status outside 4xx (including every 5xx) are treated as
+// transient, but any other 4xx (401, 403, 404, ...) will never succeed on
+// its own and should stop the reconnect loop instead of retrying forever.
+func sseReconnectable(err error) bool {
+	apiErr, ok := IsAPIError(err)
+	if !ok {
+		return true
+	}
+	if apiErr.StatusCode >= 400 && apiErr.StatusCode < 500 && apiErr.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+	return true
+}
+
+// StreamScriptExecutions opens a long-lived SSE connection to
+// /api/v1/script-executions/stream and delivers decoded events on the
+// returned channel. The event channel and error channel are both closed
+// once ctx is done or a terminal error occurs (a non-429 4xx, e.g. an
+// auth or not-found failure that will never succeed on retry); every
+// connection error, transient or terminal, is reported on the error
+// channel, but only transient ones are followed by a reconnect attempt
+// using the server-provided retry delay (or reconnection backoff).
+func (c *Client) StreamScriptExecutions(ctx context.Context, queryString string) (<-chan ScriptExecutionEvent, <-chan error, error) {
+	path := c.baseURL + apiPathPrefix + "/script-executions/stream"
+	if queryString != "" {
+		path += "?" + queryString
+	}
+
+	events := make(chan ScriptExecutionEvent)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(events)
+		defer close(errs)
+
+		lastEventID := ""
+		retryDelay := defaultSSERetryDelay
+
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+
+			body, frames, err := c.openSSEStream(ctx, path, lastEventID)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				select {
+				case errs <- fmt.Errorf("failed to stream script executions: %w", err):
+				case <-ctx.Done():
+					return
+				}
+				if !sseReconnectable(err) {
+					return
+				}
+				if !sleepWithContext(ctx, jitterDelay(retryDelay)) {
+					return
+				}
+				continue
+			}
+
+			streamErr := consumeSSE(ctx, frames, &retryDelay, func(id string) { lastEventID = id }, func(f sseFrame) error {
+				var evt ScriptExecutionEvent
+				if err := json.Unmarshal([]byte(f.data), &evt); err != nil {
+					return fmt.Errorf("failed to decode script execution event: %w", err)
+				}
+				if evt.Kind == "" {
+					evt.Kind = f.event
+				}
+				select {
+				case events <- evt:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			})
+			body.Close()
+
+			if ctx.Err() != nil {
+				return
+			}
+			if streamErr != nil {
+				select {
+				case errs <- fmt.Errorf("failed to stream script executions: %w", streamErr):
+				case <-ctx.Done():
+					return
+				}
+			}
+			if !sleepWithContext(ctx, jitterDelay(retryDelay)) {
+				return
+			}
+		}
+	}()
+
+	return events, errs, nil
+}
+
+// StreamEvents opens a long-lived SSE connection to /api/v1/events/stream
+// and delivers decoded events on the returned channel. See
+// StreamScriptExecutions for reconnection and cancellation semantics.
+func (c *Client) StreamEvents(ctx context.Context, queryString string) (<-chan StreamEvent, <-chan error, error) {
+	path := c.baseURL + apiPathPrefix + "/events/stream"
+	if queryString != "" {
+		path += "?" + queryString
+	}
+
+	events := make(chan StreamEvent)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(events)
+		defer close(errs)
+
+		lastEventID := ""
+		retryDelay := defaultSSERetryDelay
+
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+
+			body, frames, err := c.openSSEStream(ctx, path, lastEventID)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				select {
+				case errs <- fmt.Errorf("failed to stream events: %w", err):
+				case <-ctx.Done():
+					return
+				}
+				if !sseReconnectable(err) {
+					return
+				}
+				if !sleepWithContext(ctx, jitterDelay(retryDelay)) {
+					return
+				}
+				continue
+			}
+
+			streamErr := consumeSSE(ctx, frames, &retryDelay, func(id string) { lastEventID = id }, func(f sseFrame) error {
+				var evt StreamEvent
+				if err := json.Unmarshal([]byte(f.data), &evt); err != nil {
+					return fmt.Errorf("failed to decode event: %w", err)
+				}
+				if evt.Kind == "" {
+					evt.Kind = f.event
+				}
+				select {
+				case events <- evt:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			})
+			body.Close()
+
+			if ctx.Err() != nil {
+				return
+			}
+			if streamErr != nil {
+				select {
+				case errs <- fmt.Errorf("failed to stream events: %w", streamErr):
+				case <-ctx.Done():
+					return
+				}
+			}
+			if !sleepWithContext(ctx, jitterDelay(retryDelay)) {
+				return
+			}
+		}
+	}()
+
+	return events, errs, nil
+}
+
+// sseFrame is a single parsed "data:"/"event:"/"id:" block from an SSE
+// stream, i.e. the lines accumulated up to (but not including) the
+// terminating blank line.
+type sseFrame struct {
+	event string
+	data  string
+	id    string
+}
+
+func (c *Client) openSSEStream(ctx context.Context, path, lastEventID string) (io.ReadCloser, *bufio.Scanner, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, nil, parseErrorResponse(resp.StatusCode, respBody, 1, requestID(resp))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSSELineBytes)
+	return resp.Body, scanner, nil
+}
+
+// consumeSSE reads frames from scanner until the stream ends or ctx is
+// done, invoking handle for each complete frame. It updates *retryDelay
+// when the stream sends a "retry:" field, and calls onID with every
+// non-empty "id:" field it sees (including on frames with no "data:",
+// e.g. keep-alives), so callers can track Last-Event-ID without waiting
+// for a dispatched event.
+func consumeSSE(ctx context.Context, scanner *bufio.Scanner, retryDelay *time.Duration, onID func(string), handle func(sseFrame) error) error {
+	var frame sseFrame
+	var data strings.Builder
+
+	flush := func() error {
+		if frame.id != "" {
+			onID(frame.id)
+		}
+		if data.Len() == 0 && frame.event == "" {
+			return nil
+		}
+		frame.data = strings.TrimSuffix(data.String(), "\n")
+		err := handle(frame)
+		frame = sseFrame{}
+		data.Reset()
+		return err
+	}
+
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		line := scanner.Text()
+
+		switch {
+		case line == "":
+			if err := flush(); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "data:"):
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+			data.WriteByte('\n')
+		case strings.HasPrefix(line, "event:"):
+			frame.event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "id:"):
+			frame.id = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+		case strings.HasPrefix(line, "retry:"):
+			if ms, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "retry:"))); err == nil {
+				*retryDelay = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	return scanner.Err()
+}
+
+func jitterDelay(d time.Duration) time.Duration {
+	if d <= 0 {
+		return defaultSSERetryDelay
+	}
+	jitter := time.Duration(rand.Int63n(int64(d) / 2))
+	return d/2 + jitter
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,222 @@
+package processorsdk
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestConsumeSSE_IDOnlyFrameUpdatesLastEventID(t *testing.T) {
+	input := "id: 42\n\nid: 43\ndata: {}\n\n"
+	scanner := bufio.NewScanner(strings.NewReader(input))
+
+	var ids []string
+	var handled int
+	retryDelay := defaultSSERetryDelay
+	err := consumeSSE(context.Background(), scanner, &retryDelay, func(id string) {
+		ids = append(ids, id)
+	}, func(f sseFrame) error {
+		handled++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("consumeSSE returned error: %v", err)
+	}
+	if want := []string{"42", "43"}; !equalStrings(ids, want) {
+		t.Fatalf("onID calls = %v, want %v", ids, want)
+	}
+	if handled != 1 {
+		t.Fatalf("handle called %d times, want 1 (the id-only frame must not be dispatched)", handled)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestConsumeSSE_LargeDataLine(t *testing.T) {
+	large := strings.Repeat("x", 200*1024)
+	input := "data: " + large + "\n\n"
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSSELineBytes)
+
+	var got string
+	retryDelay := defaultSSERetryDelay
+	err := consumeSSE(context.Background(), scanner, &retryDelay, func(string) {}, func(f sseFrame) error {
+		got = f.data
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("consumeSSE returned error: %v", err)
+	}
+	if got != large {
+		t.Fatalf("got data of length %d, want %d", len(got), len(large))
+	}
+}
+
+// TestStreamScriptExecutions_ReconnectsAfterTransientConnectError reproduces
+// the review report: a successful stream, followed by a transient 503 on
+// reconnect, followed by a healthy stream. The client must keep retrying
+// through the connect failure instead of giving up.
+func TestStreamScriptExecutions_ReconnectsAfterTransientConnectError(t *testing.T) {
+	origDelay := defaultSSERetryDelay
+	defaultSSERetryDelay = 20 * time.Millisecond
+	defer func() { defaultSSERetryDelay = origDelay }()
+
+	var attempt int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&attempt, 1)
+		switch n {
+		case 1:
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, "id: 1\ndata: {\"id\":\"exec-1\"}\n\n")
+			w.(http.Flusher).Flush()
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"message":"temporarily unavailable"}`)
+		default:
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, "id: 2\ndata: {\"id\":\"exec-2\"}\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, errs, err := client.StreamScriptExecutions(ctx, "")
+	if err != nil {
+		t.Fatalf("StreamScriptExecutions: %v", err)
+	}
+
+	var received []string
+loop:
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				break loop
+			}
+			received = append(received, evt.Data.ID)
+			if len(received) == 2 {
+				cancel()
+			}
+		case <-errs:
+			// Expected: the transient 503 surfaces here but must not stop
+			// the reconnect loop.
+		case <-time.After(4 * time.Second):
+			break loop
+		}
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("received %v, want 2 events across the reconnect", received)
+	}
+	if atomic.LoadInt32(&attempt) < 3 {
+		t.Fatalf("server saw %d connection attempts, want at least 3 (the client must retry past the 503)", attempt)
+	}
+}
+
+// TestStreamScriptExecutions_StopsReconnectingOnPermanentError verifies
+// that a non-429 4xx connect failure (e.g. 401) is reported once and does
+// not trigger endless reconnect attempts.
+func TestStreamScriptExecutions_StopsReconnectingOnPermanentError(t *testing.T) {
+	origDelay := defaultSSERetryDelay
+	defaultSSERetryDelay = 10 * time.Millisecond
+	defer func() { defaultSSERetryDelay = origDelay }()
+
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"message":"unauthorized"}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	events, errs, err := client.StreamScriptExecutions(ctx, "")
+	if err != nil {
+		t.Fatalf("StreamScriptExecutions: %v", err)
+	}
+
+	var gotErr bool
+loop:
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				break loop
+			}
+		case _, ok := <-errs:
+			if !ok {
+				break loop
+			}
+			gotErr = true
+		case <-time.After(500 * time.Millisecond):
+			break loop
+		}
+	}
+
+	if !gotErr {
+		t.Fatal("expected the 401 to be reported on the error channel")
+	}
+	// Give any runaway reconnect loop a chance to keep hammering the
+	// server before asserting it stopped.
+	time.Sleep(100 * time.Millisecond)
+	if n := atomic.LoadInt32(&attempts); n != 1 {
+		t.Fatalf("server saw %d connection attempts, want exactly 1 (reconnect loop must stop on a permanent error)", n)
+	}
+}
+
+func TestSSEReconnectable(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"transport error", fmt.Errorf("dial tcp: connection refused"), true},
+		{"429 too many requests", &APIError{StatusCode: http.StatusTooManyRequests}, true},
+		{"503 service unavailable", &APIError{StatusCode: http.StatusServiceUnavailable}, true},
+		{"401 unauthorized", &APIError{StatusCode: http.StatusUnauthorized}, false},
+		{"403 forbidden", &APIError{StatusCode: http.StatusForbidden}, false},
+		{"404 not found", &APIError{StatusCode: http.StatusNotFound}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := sseReconnectable(tc.err); got != tc.want {
+				t.Fatalf("sseReconnectable(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
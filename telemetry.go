@@ -0,0 +1,56 @@
+package processorsdk
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics receives the outcome of every outbound call made by Client.do.
+// Implementations must be safe for concurrent use.
+type Metrics interface {
+	// ObserveRequest is called once per call with the operation name (e.g.
+	// "ListEvents"), the resulting HTTP status code (0 if the request
+	// never got a response), and the total call duration including
+	// retries.
+	ObserveRequest(op string, status int, dur time.Duration)
+}
+
+// PrometheusMetrics is the default Metrics implementation, recording a
+// request duration histogram and a request counter labeled by operation
+// and status.
+type PrometheusMetrics struct {
+	duration *prometheus.HistogramVec
+	total    *prometheus.CounterVec
+}
+
+// NewPrometheusMetrics creates a PrometheusMetrics and registers its
+// collectors with registerer. Pass prometheus.DefaultRegisterer to use the
+// global registry.
+func NewPrometheusMetrics(registerer prometheus.Registerer) (*PrometheusMetrics, error) {
+	m := &PrometheusMetrics{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "processor_sdk_request_duration_seconds",
+			Help:    "Duration of processor-service-sdk-go requests in seconds.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"op"}),
+		total: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "processor_sdk_requests_total",
+			Help: "Total number of processor-service-sdk-go requests.",
+		}, []string{"op", "status"}),
+	}
+	if err := registerer.Register(m.duration); err != nil {
+		return nil, err
+	}
+	if err := registerer.Register(m.total); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ObserveRequest implements Metrics.
+func (m *PrometheusMetrics) ObserveRequest(op string, status int, dur time.Duration) {
+	m.duration.WithLabelValues(op).Observe(dur.Seconds())
+	m.total.WithLabelValues(op, strconv.Itoa(status)).Inc()
+}
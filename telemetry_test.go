@@ -0,0 +1,41 @@
+package processorsdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestPrometheusMetrics_ObserveRequestRecordsCounterAndHistogram(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	metrics, err := NewPrometheusMetrics(reg)
+	if err != nil {
+		t.Fatalf("NewPrometheusMetrics: %v", err)
+	}
+
+	metrics.ObserveRequest("ListEvents", 200, 50*time.Millisecond)
+	metrics.ObserveRequest("ListEvents", 200, 75*time.Millisecond)
+	metrics.ObserveRequest("ListEvents", 500, 10*time.Millisecond)
+
+	if got := testutil.ToFloat64(metrics.total.WithLabelValues("ListEvents", "200")); got != 2 {
+		t.Fatalf("requests_total{op=ListEvents,status=200} = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(metrics.total.WithLabelValues("ListEvents", "500")); got != 1 {
+		t.Fatalf("requests_total{op=ListEvents,status=500} = %v, want 1", got)
+	}
+	if got := testutil.CollectAndCount(metrics.duration); got == 0 {
+		t.Fatal("expected the duration histogram to have recorded observations")
+	}
+}
+
+func TestNewPrometheusMetrics_RejectsDuplicateRegistration(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	if _, err := NewPrometheusMetrics(reg); err != nil {
+		t.Fatalf("first NewPrometheusMetrics: %v", err)
+	}
+	if _, err := NewPrometheusMetrics(reg); err == nil {
+		t.Fatal("expected the second registration against the same registerer to fail")
+	}
+}
@@ -0,0 +1,153 @@
+package processorsdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrDryRunUnsupported is returned by DryRunScript when the processor
+// service does not expose a /scripts/{id}/dry-run endpoint and no local
+// fallback is available.
+var ErrDryRunUnsupported = errors.New("processor service does not support dry-run execution")
+
+// ValidationIssue is a single problem found while validating a script.
+type ValidationIssue struct {
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Message string `json:"message"`
+}
+
+// ValidationReport is the result of ValidateScript.
+type ValidationReport struct {
+	Valid             bool              `json:"valid"`
+	SyntaxErrors      []ValidationIssue `json:"syntaxErrors,omitempty"`
+	UnresolvedImports []string          `json:"unresolvedImports,omitempty"`
+	VersionWarnings   []string          `json:"versionWarnings,omitempty"`
+}
+
+// DryRunResult is the result of DryRunScript: the ScriptExecutionItem the
+// server would have persisted, plus any captured output, without actually
+// persisting it.
+type DryRunResult struct {
+	Execution      ScriptExecutionItem    `json:"execution"`
+	Stdout         string                 `json:"stdout"`
+	Stderr         string                 `json:"stderr"`
+	MutatedPayload map[string]interface{} `json:"mutatedPayload,omitempty"`
+}
+
+// Validator performs a client-side syntax check for a script of a given
+// type (matches ScriptItem.Type / CreateScriptBody.Type, e.g. "js",
+// "lua"). Implementations should only report syntax errors and unresolved
+// imports; they are not expected to execute the script.
+type Validator interface {
+	Validate(code string) (syntaxErrors []ValidationIssue, unresolvedImports []string, err error)
+}
+
+// RegisterValidator registers a client-side Validator for the given script
+// type, used by ValidateScript as a fallback when the processor service
+// does not expose a /scripts/validate endpoint.
+func (c *Client) RegisterValidator(scriptType string, v Validator) {
+	if c.validators == nil {
+		c.validators = make(map[string]Validator)
+	}
+	c.validators[scriptType] = v
+}
+
+// ValidateScript checks a script for syntax errors, unresolved imports and
+// version collisions before it is created or updated. If the processor
+// service exposes POST /api/v1/scripts/validate that endpoint is used;
+// otherwise version collisions are checked against ListScripts and syntax
+// is checked with a registered Validator for body.Type, if any.
+func (c *Client) ValidateScript(ctx context.Context, body CreateScriptBody) (*ValidationReport, error) {
+	path := c.baseURL + apiPathPrefix + "/scripts/validate"
+	raw, _ := json.Marshal(body)
+	var resp struct {
+		Success bool             `json:"success"`
+		Message string           `json:"message"`
+		Status  int              `json:"status"`
+		Data    ValidationReport `json:"data"`
+	}
+	err := c.do(ctx, http.MethodPost, path, raw, []int{http.StatusOK}, &resp, "failed to validate script",
+		opInfo{Name: "ValidateScript", Resource: "scripts"})
+	if err == nil {
+		return &resp.Data, nil
+	}
+	apiErr, ok := IsAPIError(err)
+	if !ok || apiErr.StatusCode != http.StatusNotFound {
+		return nil, err
+	}
+
+	report := &ValidationReport{Valid: true}
+
+	versionWarnings, err := c.checkVersionCollision(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	report.VersionWarnings = versionWarnings
+
+	if v, ok := c.validators[body.Type]; ok {
+		syntaxErrors, unresolvedImports, err := v.Validate(body.Code)
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate script: %w", err)
+		}
+		report.SyntaxErrors = syntaxErrors
+		report.UnresolvedImports = unresolvedImports
+	}
+
+	report.Valid = len(report.SyntaxErrors) == 0
+	return report, nil
+}
+
+// checkVersionCollision looks for existing scripts with the same name and
+// service whose version matches body.Version, walking every page of
+// matches rather than just the first so collisions on later pages aren't
+// missed.
+func (c *Client) checkVersionCollision(ctx context.Context, body CreateScriptBody) ([]string, error) {
+	if body.Version == "" {
+		return nil, nil
+	}
+	query := ScriptListQuery{Service: body.Service}
+	query.Filter("name", "eq", body.Name)
+
+	var warnings []string
+	for existing, err := range c.IterateScripts(ctx, query) {
+		if err != nil {
+			return nil, err
+		}
+		if existing.Name == body.Name && existing.Version == body.Version {
+			warnings = append(warnings, fmt.Sprintf("script %q already has a version %q (id %s)", body.Name, body.Version, existing.ID))
+		}
+	}
+	return warnings, nil
+}
+
+// DryRunScript executes a script against samplePayload without persisting
+// the resulting ScriptExecutionItem, using POST
+// /api/v1/scripts/{id}/dry-run. There is no client-side fallback since
+// running a script requires the processor service's execution sandbox; if
+// the endpoint is missing, ErrDryRunUnsupported is returned.
+func (c *Client) DryRunScript(ctx context.Context, id string, samplePayload map[string]interface{}) (*DryRunResult, error) {
+	if id == "" {
+		return nil, fmt.Errorf("script id is required")
+	}
+	path := c.baseURL + apiPathPrefix + "/scripts/" + pathSeg(id) + "/dry-run"
+	raw, _ := json.Marshal(map[string]interface{}{"payload": samplePayload})
+	var resp struct {
+		Success bool         `json:"success"`
+		Message string       `json:"message"`
+		Status  int          `json:"status"`
+		Data    DryRunResult `json:"data"`
+	}
+	err := c.do(ctx, http.MethodPost, path, raw, []int{http.StatusOK}, &resp, "failed to dry-run script",
+		opInfo{Name: "DryRunScript", Resource: "scripts", ResourceID: id})
+	if err != nil {
+		if apiErr, ok := IsAPIError(err); ok && apiErr.StatusCode == http.StatusNotFound {
+			return nil, ErrDryRunUnsupported
+		}
+		return nil, err
+	}
+	return &resp.Data, nil
+}
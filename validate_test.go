@@ -0,0 +1,164 @@
+package processorsdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidateScript_UsesServerEndpointWhenAvailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/scripts/validate" {
+			t.Fatalf("unexpected path %q", r.URL.Path)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"data": ValidationReport{Valid: true},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	report, err := client.ValidateScript(context.Background(), CreateScriptBody{Name: "s", Type: "js"})
+	if err != nil {
+		t.Fatalf("ValidateScript: %v", err)
+	}
+	if !report.Valid {
+		t.Fatal("expected the server-reported report to be used as-is")
+	}
+}
+
+// TestValidateScript_FallsBackOnNotFound exercises the client-side path:
+// no /scripts/validate endpoint, a version collision found across two
+// pages of ListScripts, and a registered Validator reporting a syntax
+// error.
+func TestValidateScript_FallsBackOnNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/scripts/validate":
+			writeJSON(w, http.StatusNotFound, map[string]string{"message": "not found"})
+		case "/api/v1/scripts":
+			page := r.URL.Query().Get("page")
+			if page == "" || page == "1" {
+				next := 2
+				writeJSON(w, http.StatusOK, ListScriptsResponse{
+					Data:       []ScriptItem{{ID: "other", Name: "s", Version: "0.9.0"}},
+					Pagination: &Pagination{NextPage: &next},
+				})
+				return
+			}
+			writeJSON(w, http.StatusOK, ListScriptsResponse{
+				Data:       []ScriptItem{{ID: "collider", Name: "s", Version: "1.0.0"}},
+				Pagination: &Pagination{},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	client.RegisterValidator("js", stubValidator{
+		syntaxErrors: []ValidationIssue{{Line: 1, Message: "unexpected token"}},
+	})
+
+	report, err := client.ValidateScript(context.Background(), CreateScriptBody{Name: "s", Type: "js", Version: "1.0.0", Code: "bad("})
+	if err != nil {
+		t.Fatalf("ValidateScript: %v", err)
+	}
+	if report.Valid {
+		t.Fatal("expected report.Valid = false due to the registered validator's syntax error")
+	}
+	if len(report.VersionWarnings) != 1 {
+		t.Fatalf("VersionWarnings = %v, want exactly the page-2 collision", report.VersionWarnings)
+	}
+	if len(report.SyntaxErrors) != 1 {
+		t.Fatalf("SyntaxErrors = %v, want the registered validator's error", report.SyntaxErrors)
+	}
+}
+
+func TestValidateScript_NoRegisteredValidator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/scripts/validate":
+			writeJSON(w, http.StatusNotFound, map[string]string{"message": "not found"})
+		case "/api/v1/scripts":
+			writeJSON(w, http.StatusOK, ListScriptsResponse{Data: nil, Pagination: &Pagination{}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	report, err := client.ValidateScript(context.Background(), CreateScriptBody{Name: "s", Type: "lua", Code: "x = 1"})
+	if err != nil {
+		t.Fatalf("ValidateScript: %v", err)
+	}
+	if !report.Valid {
+		t.Fatalf("expected Valid = true with no registered validator and no version collisions, got %+v", report)
+	}
+}
+
+func TestDryRunScript_ReturnsErrDryRunUnsupportedOn404(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "not found"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	_, err = client.DryRunScript(context.Background(), "abc", nil)
+	if !errors.Is(err, ErrDryRunUnsupported) {
+		t.Fatalf("DryRunScript err = %v, want ErrDryRunUnsupported", err)
+	}
+}
+
+func TestDryRunScript_ReturnsServerResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"data": DryRunResult{Stdout: "ok"},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	result, err := client.DryRunScript(context.Background(), "abc", map[string]interface{}{"k": "v"})
+	if err != nil {
+		t.Fatalf("DryRunScript: %v", err)
+	}
+	if result.Stdout != "ok" {
+		t.Fatalf("Stdout = %q, want %q", result.Stdout, "ok")
+	}
+}
+
+type stubValidator struct {
+	syntaxErrors      []ValidationIssue
+	unresolvedImports []string
+}
+
+func (s stubValidator) Validate(code string) ([]ValidationIssue, []string, error) {
+	return s.syntaxErrors, s.unresolvedImports, nil
+}